@@ -0,0 +1,343 @@
+package uiprogress
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Fill represents the default character to fill the progress bar with
+var Fill byte = '='
+
+// Head is the default character that moves as the progress bar advances
+var Head byte = '>'
+
+// Empty is the default character to fill the remaining space with
+var Empty byte = '-'
+
+// LeftEnd is the default character in the left most part of the progress indicator
+var LeftEnd byte = '['
+
+// RightEnd is the default character in the right most part of the progress indicator
+var RightEnd byte = ']'
+
+// Width is the default width of the progress bar
+var Width = 70
+
+// DecoratorFunc is a function that can be prepended and appended to the progress bar
+type DecoratorFunc func(b *Bar) string
+
+// Bar represents a progress bar
+type Bar struct {
+	// Total is the total number of units the bar represents completion of
+	Total int
+
+	// LeftEnd is the left character of the progress indicator
+	LeftEnd byte
+
+	// RightEnd is the right character of the progress indicator
+	RightEnd byte
+
+	// Fill is the character that represents completed progress
+	Fill byte
+
+	// Head is the character that moves as the bar progresses
+	Head byte
+
+	// Empty is the character that represents the remaining, uncompleted progress
+	Empty byte
+
+	// Width is the width of the progress bar
+	Width int
+
+	// TimeStarted is the time the progress bar started
+	TimeStarted time.Time
+
+	current int
+
+	// version is bumped on every state change made through Set/IncrBy, letting
+	// a Progress detect whether the bar needs to be redrawn
+	version int
+
+	// lastIncrTime is the timestamp of the previous Incr/IncrBy/Set call, used
+	// to sample per-item durations for the ETA's exponentially-weighted moving average
+	lastIncrTime time.Time
+
+	// avgDuration is the EWMA of the time it takes to complete one unit of Total
+	avgDuration time.Duration
+
+	// sampleCount counts the samples that have fed avgDuration, up to
+	// emaWarmupSamples, so the first few samples can use a plain running mean
+	// instead of the EWMA to avoid a wildly inaccurate ETA while the average
+	// is still warming up
+	sampleCount int
+
+	mtx *sync.RWMutex
+
+	prependFuncs []Decorator
+	appendFuncs  []Decorator
+
+	// onComplete is called exactly once, the first time current reaches Total
+	onComplete func(*Bar)
+
+	// completeFired records whether onComplete has already been invoked
+	completeFired bool
+}
+
+// emaAlpha is the smoothing factor used once avgDuration has warmed up
+const emaAlpha = 0.25
+
+// emaWarmupSamples is the number of samples during which avgDuration is a
+// plain running mean rather than an EWMA, to avoid ETA spikes on the first increments
+const emaWarmupSamples = 8
+
+// NewBar returns a new progress bar
+func NewBar(total int) *Bar {
+	return &Bar{
+		Total:       total,
+		Width:       Width,
+		LeftEnd:     LeftEnd,
+		RightEnd:    RightEnd,
+		Fill:        Fill,
+		Head:        Head,
+		Empty:       Empty,
+		TimeStarted: time.Now(),
+		mtx:         &sync.RWMutex{},
+	}
+}
+
+// PrependFunc runs the decorator function and prepends the output to the progress bar
+func (b *Bar) PrependFunc(f DecoratorFunc) *Bar {
+	return b.PrependDecorator(&funcDecorator{fn: f})
+}
+
+// AppendFunc runs the decorator function and appends the output to the progress bar
+func (b *Bar) AppendFunc(f DecoratorFunc) *Bar {
+	return b.AppendDecorator(&funcDecorator{fn: f})
+}
+
+// PrependDecorator prepends d to the progress bar. Unlike PrependFunc, d may
+// take part in a width-sync group (see DecoratorGroup) so its rendered
+// column stays aligned with the same decorator on other bars.
+func (b *Bar) PrependDecorator(d Decorator) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.prependFuncs = append(b.prependFuncs, d)
+	return b
+}
+
+// AppendDecorator appends d to the progress bar. Unlike AppendFunc, d may
+// take part in a width-sync group (see DecoratorGroup) so its rendered
+// column stays aligned with the same decorator on other bars.
+func (b *Bar) AppendDecorator(d Decorator) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.appendFuncs = append(b.appendFuncs, d)
+	return b
+}
+
+// decorators returns copies of the bar's prepend and append decorators
+func (b *Bar) decorators() (prepend, append []Decorator) {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	prepend = make([]Decorator, len(b.prependFuncs))
+	copy(prepend, b.prependFuncs)
+	append = make([]Decorator, len(b.appendFuncs))
+	copy(append, b.appendFuncs)
+	return
+}
+
+// Set sets the current count of the bar. It returns an error if n exceeds Total
+func (b *Bar) Set(n int) error {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if n > b.Total {
+		return fmt.Errorf("current number %d exceeds total number %d", n, b.Total)
+	}
+	if n == b.current {
+		return nil
+	}
+	b.sample(n - b.current)
+	b.current = n
+	b.version++
+	return nil
+}
+
+// Incr increments the current count of the bar by one. It returns false once
+// the bar has reached Total
+func (b *Bar) Incr() bool {
+	return b.IncrBy(1)
+}
+
+// IncrBy increments the current count of the bar by n, clamping to Total. It
+// returns false once the bar has reached Total
+func (b *Bar) IncrBy(n int) bool {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.current >= b.Total {
+		return false
+	}
+
+	next := b.current + n
+	if next > b.Total {
+		next = b.Total
+	}
+	if next == b.current {
+		return true
+	}
+	b.sample(next - b.current)
+	b.current = next
+	b.version++
+	return true
+}
+
+// versionSnapshot returns the bar's current dirty-tracking version, so a
+// Progress can tell whether a bar needs to be redrawn without re-rendering it.
+func (b *Bar) versionSnapshot() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.version
+}
+
+// sample updates the EWMA of the per-unit duration from the time elapsed
+// since the previous increment. Callers must hold mtx.
+func (b *Bar) sample(delta int) {
+	now := time.Now()
+	if b.lastIncrTime.IsZero() || delta <= 0 {
+		b.lastIncrTime = now
+		return
+	}
+
+	dt := now.Sub(b.lastIncrTime) / time.Duration(delta)
+	b.lastIncrTime = now
+
+	if b.sampleCount < emaWarmupSamples {
+		b.sampleCount++
+		b.avgDuration += (dt - b.avgDuration) / time.Duration(b.sampleCount)
+		return
+	}
+
+	b.avgDuration = time.Duration(emaAlpha*float64(dt) + (1-emaAlpha)*float64(b.avgDuration))
+}
+
+// OnComplete registers f to be called exactly once, the first time the bar's
+// current count reaches Total. The callback fires outside of the owning
+// Progress's mutex, so it may safely call back into AddBar/RemoveBar.
+func (b *Bar) OnComplete(f func(*Bar)) *Bar {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.onComplete = f
+	return b
+}
+
+// consumeCompletion returns the registered onComplete callback the first
+// time it is called after the bar reaches Total, and nil on every other
+// call. Callers should invoke the returned callback without holding b.mtx.
+func (b *Bar) consumeCompletion() func(*Bar) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if b.completeFired || b.current < b.Total || b.onComplete == nil {
+		return nil
+	}
+	b.completeFired = true
+	return b.onComplete
+}
+
+// Current returns the current count of the bar
+func (b *Bar) Current() int {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.current
+}
+
+// TimeElapsed returns the time elapsed since the bar started
+func (b *Bar) TimeElapsed() time.Duration {
+	return time.Since(b.TimeStarted)
+}
+
+// TimeRemaining returns the estimated time remaining based on the EWMA of
+// per-unit durations sampled from Incr/IncrBy/Set calls. It returns 0 until
+// enough samples have been collected to produce an estimate.
+func (b *Bar) TimeRemaining() time.Duration {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	if b.sampleCount == 0 || b.current >= b.Total {
+		return 0
+	}
+	return b.avgDuration * time.Duration(b.Total-b.current)
+}
+
+// Rate returns the current rate of progress in units per second, derived
+// from the same EWMA of per-item durations that backs TimeRemaining. It
+// returns 0 until enough samples have been collected to produce an estimate.
+func (b *Bar) Rate() float64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+
+	if b.sampleCount == 0 || b.avgDuration <= 0 {
+		return 0
+	}
+	return float64(time.Second) / float64(b.avgDuration)
+}
+
+// CompletedPercent returns the percent completed as a number between 0 and 100
+func (b *Bar) CompletedPercent() float64 {
+	b.mtx.RLock()
+	defer b.mtx.RUnlock()
+	return b.percent()
+}
+
+func (b *Bar) percent() float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return (float64(b.current) / float64(b.Total)) * 100.00
+}
+
+// String renders the progress bar, including any prepended and appended decorators
+func (b *Bar) String() string {
+	// Decorators are run without holding b.mtx: many, including the built-in
+	// ETA/Rate decorators, call back into locking Bar methods, and a second
+	// RLock from the same goroutine is not guaranteed to succeed once a
+	// writer (Set/IncrBy) is queued behind the first one.
+	prependDecs, appendDecs := b.decorators()
+
+	var prepend, append string
+	for _, d := range prependDecs {
+		prepend += d.Decor(b) + " "
+	}
+	for _, d := range appendDecs {
+		append += " " + d.Decor(b)
+	}
+
+	b.mtx.RLock()
+	rendered := b.render()
+	b.mtx.RUnlock()
+
+	return prepend + rendered + append
+}
+
+func (b *Bar) render() string {
+	completedWidth := int(math.Ceil(float64(b.Width) * (b.percent() / 100.00)))
+
+	buf := bytes.NewBuffer(nil)
+	for i := 0; i < completedWidth; i++ {
+		if i == completedWidth-1 && completedWidth < b.Width {
+			buf.WriteByte(b.Head)
+		} else {
+			buf.WriteByte(b.Fill)
+		}
+	}
+	for i := completedWidth; i < b.Width; i++ {
+		buf.WriteByte(b.Empty)
+	}
+
+	return fmt.Sprintf("%c%s%c", b.LeftEnd, buf.String(), b.RightEnd)
+}
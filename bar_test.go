@@ -0,0 +1,151 @@
+package uiprogress
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBarIncrByEMASmoothing(t *testing.T) {
+	b := NewBar(1000)
+
+	// Warm up avgDuration with steady increments so it settles near the
+	// observed per-call duration.
+	for i := 0; i < emaWarmupSamples+4; i++ {
+		if !b.IncrBy(1) {
+			t.Fatalf("IncrBy unexpectedly returned false at iteration %d", i)
+		}
+	}
+
+	if b.sampleCount == 0 {
+		t.Fatalf("expected sampleCount to be nonzero after warm-up")
+	}
+	if b.avgDuration <= 0 {
+		t.Fatalf("expected avgDuration to be positive after warm-up, got %v", b.avgDuration)
+	}
+
+	remaining := b.TimeRemaining()
+	if remaining <= 0 {
+		t.Fatalf("expected a positive TimeRemaining estimate, got %v", remaining)
+	}
+
+	rate := b.Rate()
+	if rate <= 0 {
+		t.Fatalf("expected a positive Rate estimate, got %v", rate)
+	}
+}
+
+func TestBarTimeRemainingZeroBeforeSamples(t *testing.T) {
+	b := NewBar(10)
+	if got := b.TimeRemaining(); got != 0 {
+		t.Fatalf("TimeRemaining() = %v before any samples, want 0", got)
+	}
+	if got := b.Rate(); got != 0 {
+		t.Fatalf("Rate() = %v before any samples, want 0", got)
+	}
+}
+
+func TestBarTimeRemainingZeroWhenComplete(t *testing.T) {
+	b := NewBar(1)
+	b.IncrBy(1)
+	if got := b.TimeRemaining(); got != 0 {
+		t.Fatalf("TimeRemaining() = %v once complete, want 0", got)
+	}
+}
+
+func TestBarSetNoOpDoesNotBumpVersion(t *testing.T) {
+	b := NewBar(10)
+	b.Set(5)
+	v := b.versionSnapshot()
+
+	if err := b.Set(5); err != nil {
+		t.Fatalf("Set(5) returned error: %v", err)
+	}
+	if got := b.versionSnapshot(); got != v {
+		t.Fatalf("versionSnapshot() = %d after no-op Set, want unchanged %d", got, v)
+	}
+}
+
+func TestBarIncrByZeroDoesNotBumpVersion(t *testing.T) {
+	b := NewBar(10)
+	b.IncrBy(3)
+	v := b.versionSnapshot()
+
+	if !b.IncrBy(0) {
+		t.Fatalf("IncrBy(0) returned false, want true (no-op, not a failure)")
+	}
+	if got := b.versionSnapshot(); got != v {
+		t.Fatalf("versionSnapshot() = %d after IncrBy(0), want unchanged %d", got, v)
+	}
+	if got := b.Current(); got != 3 {
+		t.Fatalf("Current() = %d after IncrBy(0), want unchanged 3", got)
+	}
+}
+
+func TestBarIncrByBumpsVersion(t *testing.T) {
+	b := NewBar(10)
+	v0 := b.versionSnapshot()
+
+	b.IncrBy(2)
+	v1 := b.versionSnapshot()
+	if v1 == v0 {
+		t.Fatalf("versionSnapshot() did not change after IncrBy(2)")
+	}
+}
+
+func TestBarConsumeCompletionFiresOnce(t *testing.T) {
+	b := NewBar(1)
+	var called int
+	b.OnComplete(func(*Bar) { called++ })
+
+	if fire := b.consumeCompletion(); fire != nil {
+		t.Fatalf("consumeCompletion fired before the bar reached Total")
+	}
+
+	b.Incr()
+
+	fire := b.consumeCompletion()
+	if fire == nil {
+		t.Fatalf("expected consumeCompletion to return the callback once Total is reached")
+	}
+	fire(b)
+	if called != 1 {
+		t.Fatalf("callback called %d times, want 1", called)
+	}
+
+	if fire := b.consumeCompletion(); fire != nil {
+		t.Fatalf("expected consumeCompletion to return nil on subsequent calls")
+	}
+	if called != 1 {
+		t.Fatalf("callback called %d times after a second consumeCompletion, want still 1", called)
+	}
+}
+
+// TestBarStringDoesNotDeadlockWithETADecorator guards against String()
+// holding b.mtx's RLock while calling a decorator that itself calls back
+// into a locking Bar method (AppendETA/AppendRate do this via
+// TimeRemaining/Rate). A second RLock from the same goroutine is not
+// guaranteed to succeed once a concurrent Set/IncrBy is queued on Lock, so
+// this exercises String and IncrBy concurrently with an ETA decorator attached.
+func TestBarStringDoesNotDeadlockWithETADecorator(t *testing.T) {
+	b := NewBar(1000)
+	b.PrependFunc(AppendETA())
+	b.AppendFunc(AppendRate())
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 200; i++ {
+			b.IncrBy(1)
+		}
+		close(done)
+	}()
+
+	for i := 0; i < 200; i++ {
+		_ = b.String()
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("String()/IncrBy() deadlocked with an ETA/Rate decorator attached")
+	}
+}
@@ -0,0 +1,116 @@
+package uiprogress
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// AppendETA appends the estimated time remaining, computed from an EWMA of
+// the per-item durations sampled on Incr/IncrBy/Set, to the progress bar
+func AppendETA() DecoratorFunc {
+	return func(b *Bar) string {
+		return etaString(b)
+	}
+}
+
+// PrependETA prepends the estimated time remaining, computed from an EWMA of
+// the per-item durations sampled on Incr/IncrBy/Set, to the progress bar
+func PrependETA() DecoratorFunc {
+	return func(b *Bar) string {
+		return etaString(b)
+	}
+}
+
+func etaString(b *Bar) string {
+	remaining := b.TimeRemaining()
+	if remaining == 0 {
+		return "ETA: --"
+	}
+	return fmt.Sprintf("ETA: %s", remaining.Round(time.Second))
+}
+
+// AppendRate appends the current transfer rate, computed from the same EWMA
+// of per-item durations that backs the ETA and formatted as a human-readable
+// bytes-per-second figure, to the progress bar. This is the counterpart to
+// AppendETA for the "download progress from an HTTP body" use case: pair it
+// with ProxyReader/ProxyWriter to show live throughput.
+func AppendRate() DecoratorFunc {
+	return func(b *Bar) string {
+		return rateString(b)
+	}
+}
+
+// PrependRate prepends the current transfer rate, computed from the same
+// EWMA of per-item durations that backs the ETA and formatted as a
+// human-readable bytes-per-second figure, to the progress bar.
+func PrependRate() DecoratorFunc {
+	return func(b *Bar) string {
+		return rateString(b)
+	}
+}
+
+func rateString(b *Bar) string {
+	rate := b.Rate()
+	if rate == 0 {
+		return "-- /s"
+	}
+	return fmt.Sprintf("%s/s", humanBytes(rate))
+}
+
+// humanBytes formats v (e.g. bytes per second) using binary (1024) unit
+// prefixes, with one decimal place
+func humanBytes(v float64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	i := 0
+	for v >= 1024 && i < len(units)-1 {
+		v /= 1024
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", v, units[i])
+}
+
+// proxyReader wraps an io.Reader, calling IncrBy on the wrapped Bar for every
+// byte read so the bar's EWMA-based ETA tracks the read rate automatically
+type proxyReader struct {
+	io.Reader
+	bar *Bar
+}
+
+func (r *proxyReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.bar.IncrBy(n)
+	}
+	return n, err
+}
+
+// ProxyReader returns an io.Reader that proxies reads through r, calling
+// IncrBy(n) on the bar for every n bytes read. Total should be set to the
+// expected number of bytes (e.g. an HTTP response's Content-Length) for the
+// ETA to be meaningful.
+func (b *Bar) ProxyReader(r io.Reader) io.Reader {
+	return &proxyReader{Reader: r, bar: b}
+}
+
+// proxyWriter wraps an io.Writer, calling IncrBy on the wrapped Bar for every
+// byte written so the bar's EWMA-based ETA tracks the write rate automatically
+type proxyWriter struct {
+	io.Writer
+	bar *Bar
+}
+
+func (w *proxyWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	if n > 0 {
+		w.bar.IncrBy(n)
+	}
+	return n, err
+}
+
+// ProxyWriter returns an io.Writer that proxies writes through w, calling
+// IncrBy(n) on the bar for every n bytes written. Total should be set to the
+// expected number of bytes for the ETA to be meaningful.
+func (b *Bar) ProxyWriter(w io.Writer) io.Writer {
+	return &proxyWriter{Writer: w, bar: b}
+}
@@ -0,0 +1,58 @@
+package uiprogress
+
+import "sync"
+
+// Decorator is the interface satisfied by anything that can be prepended or
+// appended to a Bar. DecoratorFunc values are automatically adapted to it.
+type Decorator interface {
+	// Decor returns the decorator's rendered content for b
+	Decor(b *Bar) string
+
+	// Sync reports the width-sync group this decorator participates in, and
+	// the width of the content produced by the most recent Decor call. A
+	// blank group means the decorator does not participate in width syncing.
+	Sync() (group string, width int)
+}
+
+// funcDecorator adapts a plain DecoratorFunc to the Decorator interface. It
+// belongs to no width-sync group.
+type funcDecorator struct {
+	fn DecoratorFunc
+}
+
+func (d *funcDecorator) Decor(b *Bar) string { return d.fn(b) }
+func (d *funcDecorator) Sync() (string, int) { return "", 0 }
+
+// DecoratorGroup wraps a DecoratorFunc so that its rendered content is
+// padded to the widest content observed for Group across all bars during a
+// single Progress.print() pass. Bars that declare a prepend or append
+// decorator with the same Group render that column at a consistent width,
+// which keeps decorators of varying length (bar names, ETAs, ...) lined up.
+type DecoratorGroup struct {
+	// Group is the width-sync key shared across bars
+	Group string
+
+	// Func produces the decorator's unpadded content for the bar
+	Func DecoratorFunc
+
+	mtx       sync.Mutex
+	lastWidth int
+}
+
+// Decor renders Func and records the resulting width for Sync
+func (d *DecoratorGroup) Decor(b *Bar) string {
+	s := d.Func(b)
+
+	d.mtx.Lock()
+	d.lastWidth = len(s)
+	d.mtx.Unlock()
+
+	return s
+}
+
+// Sync returns Group and the width recorded by the most recent Decor call
+func (d *DecoratorGroup) Sync() (string, int) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return d.Group, d.lastWidth
+}
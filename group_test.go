@@ -0,0 +1,75 @@
+package uiprogress
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestDecoratorGroupSyncReportsLastDecorWidth(t *testing.T) {
+	b := NewBar(10)
+	g := &DecoratorGroup{Group: "name", Func: func(*Bar) string { return "abc" }}
+
+	g.Decor(b)
+	group, width := g.Sync()
+	if group != "name" {
+		t.Fatalf("Sync() group = %q, want %q", group, "name")
+	}
+	if width != 3 {
+		t.Fatalf("Sync() width = %d, want 3", width)
+	}
+}
+
+// TestDecoratorGroupAlignsAcrossBars exercises Progress.render()'s two-pass
+// width-sync: two bars prepend a DecoratorGroup sharing the same Group key
+// but producing content of different lengths, and the shorter one should be
+// padded out to the longer one's width so both bars' '[' line up.
+func TestDecoratorGroupAlignsAcrossBars(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+
+	short := p.AddBar(10)
+	long := p.AddBar(10)
+
+	short.PrependDecorator(&DecoratorGroup{Group: "name", Func: func(*Bar) string { return "a" }})
+	long.PrependDecorator(&DecoratorGroup{Group: "name", Func: func(*Bar) string { return "abcdef" }})
+
+	completions := p.render()
+	for _, fire := range completions {
+		fire()
+	}
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 rendered lines, got %d: %q", len(lines), buf.String())
+	}
+
+	barStart := func(line string) int { return strings.IndexByte(line, '[') }
+	if barStart(lines[0]) != barStart(lines[1]) {
+		t.Fatalf("expected synced decorator columns to align, got bar starts at %d and %d:\n%q\n%q",
+			barStart(lines[0]), barStart(lines[1]), lines[0], lines[1])
+	}
+}
+
+// TestUngroupedDecoratorsAreNotPadded ensures plain DecoratorFunc values
+// (adapted via funcDecorator, Group == "") are left untouched by the
+// width-sync pass.
+func TestUngroupedDecoratorsAreNotPadded(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+
+	bar := p.AddBar(10)
+	bar.PrependFunc(func(*Bar) string { return "x" })
+
+	completions := p.render()
+	for _, fire := range completions {
+		fire()
+	}
+
+	line := strings.TrimRight(buf.String(), "\n")
+	if !strings.HasPrefix(line, "x [") {
+		t.Fatalf("expected an unpadded single-character prefix, got %q", line)
+	}
+}
@@ -1,9 +1,11 @@
 package uiprogress
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
@@ -45,12 +47,33 @@ type Progress struct {
 
 	lw     *uilive.Writer
 	ticker *time.Ticker
-	tdone  chan bool
 	mtx    *sync.RWMutex
+
+	ctx context.Context
+
+	// done is closed by Stop to ask Listen to exit
+	done chan struct{}
+	// listenDone is closed by Listen when its loop has exited, for Stop to wait on
+	listenDone chan struct{}
+	stopOnce   sync.Once
+
+	// throttle is the minimum time between renders; zero means unthrottled
+	throttle   time.Duration
+	lastRender time.Time
+	// barVersions records each bar's versionSnapshot as of the last render,
+	// so print() can skip the uilive flush when nothing has changed
+	barVersions map[*Bar]int
 }
 
 // New returns a new progress bar with defaults
 func New() *Progress {
+	return NewWithContext(context.Background())
+}
+
+// NewWithContext returns a new progress bar with defaults, whose Listen loop
+// also exits when ctx is done. This lets bar lifetime be tied to a request
+// or command context instead of only an explicit Stop call.
+func NewWithContext(ctx context.Context) *Progress {
 	lw := uilive.New()
 	lw.Out = Out
 
@@ -60,12 +83,25 @@ func New() *Progress {
 		Bars:            make([]*Bar, 0),
 		RefreshInterval: RefreshInterval,
 
-		tdone: make(chan bool),
-		lw:    lw,
-		mtx:   &sync.RWMutex{},
+		ctx:         ctx,
+		done:        make(chan struct{}),
+		listenDone:  make(chan struct{}),
+		barVersions: make(map[*Bar]int),
+		lw:          lw,
+		mtx:         &sync.RWMutex{},
 	}
 }
 
+// SetThrottle sets the minimum time between renders, coalescing rapid
+// updates (e.g. from an io.Reader proxy called on every read) so the render
+// loop does not redraw on every RefreshInterval tick regardless of how often
+// bar state actually changed. A zero duration, the default, disables throttling.
+func (p *Progress) SetThrottle(min time.Duration) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	p.throttle = min
+}
+
 // AddBar creates a new progress bar and adds it to the default progress container
 func AddBar(total int) *Bar {
 	return defaultProgress.AddBar(total)
@@ -86,6 +122,16 @@ func Listen() {
 	defaultProgress.Listen()
 }
 
+// SetThrottle sets the minimum time between renders on the default progress container
+func SetThrottle(min time.Duration) {
+	defaultProgress.SetThrottle(min)
+}
+
+// RenderNow forces an immediate, synchronous render of the default progress container
+func RenderNow() {
+	defaultProgress.RenderNow()
+}
+
 func (p *Progress) SetOut(o io.Writer) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
@@ -111,10 +157,40 @@ func (p *Progress) AddBar(total int) *Bar {
 	return bar
 }
 
-// Listen listens for updates and renders the progress bars
+// RemoveBar removes bar from the container so it is no longer rendered. It
+// returns false if bar was not found.
+func (p *Progress) RemoveBar(bar *Bar) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for i, b := range p.Bars {
+		if b == bar {
+			p.Bars = append(p.Bars[:i], p.Bars[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// SortBars reorders the container's bars in place using less as the sort
+// comparator. less is called with the bars themselves rather than their
+// positions, and the sort is stable.
+func (p *Progress) SortBars(less func(i, j *Bar) bool) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	sort.SliceStable(p.Bars, func(i, j int) bool {
+		return less(p.Bars[i], p.Bars[j])
+	})
+}
+
+// Listen listens for updates and renders the progress bars. It returns when
+// Stop is called or, for a Progress created with NewWithContext, when the
+// context is done.
 func (p *Progress) Listen() {
-	for {
+	defer close(p.listenDone)
 
+	for {
 		p.mtx.Lock()
 		interval := p.RefreshInterval
 		p.mtx.Unlock()
@@ -122,41 +198,146 @@ func (p *Progress) Listen() {
 		select {
 		case <-time.After(interval):
 			p.print()
-		case <-p.tdone:
+		case <-p.ctx.Done():
+			p.print()
+			return
+		case <-p.done:
 			p.print()
-			close(p.tdone)
 			return
 		}
 	}
 }
 
 func (p *Progress) print() {
+	p.mtx.Lock()
+	changed := p.barsChangedLocked()
+	throttled := p.throttle > 0 && !p.lastRender.IsZero() && time.Since(p.lastRender) < p.throttle
+	if !changed || throttled {
+		p.mtx.Unlock()
+		return
+	}
+	p.lastRender = time.Now()
+	p.commitBarVersionsLocked()
+	p.mtx.Unlock()
+
+	p.flush()
+}
+
+// RenderNow forces an immediate, synchronous render, bypassing both the
+// dirty check and SetThrottle. Callers that want a guaranteed final frame
+// (e.g. right before Stop) should use this instead of waiting on the next tick.
+func (p *Progress) RenderNow() {
+	p.mtx.Lock()
+	p.lastRender = time.Now()
+	p.commitBarVersionsLocked()
+	p.mtx.Unlock()
+
+	p.flush()
+}
+
+// flush renders every bar and fires any completion callbacks that became due
+func (p *Progress) flush() {
+	completions := p.render()
+
+	// Completion callbacks run outside of p.mtx so they may safely call back
+	// into AddBar/RemoveBar without deadlocking.
+	for _, fire := range completions {
+		fire()
+	}
+}
+
+// barsChangedLocked reports whether any bar has been added, removed, or has
+// changed its versionSnapshot since the last committed render. Callers must
+// hold p.mtx.
+func (p *Progress) barsChangedLocked() bool {
+	if len(p.Bars) != len(p.barVersions) {
+		return true
+	}
+	for _, bar := range p.Bars {
+		v, ok := p.barVersions[bar]
+		if !ok || v != bar.versionSnapshot() {
+			return true
+		}
+	}
+	return false
+}
+
+// commitBarVersionsLocked records each current bar's versionSnapshot as the
+// baseline for the next barsChangedLocked check. Callers must hold p.mtx.
+func (p *Progress) commitBarVersionsLocked() {
+	versions := make(map[*Bar]int, len(p.Bars))
+	for _, bar := range p.Bars {
+		versions[bar] = bar.versionSnapshot()
+	}
+	p.barVersions = versions
+}
+
+// decorContent is a decorator's rendered content for one bar, along with the
+// width-sync group (if any) it should be padded to before rendering
+type decorContent struct {
+	content string
+	group   string
+}
+
+// render draws every bar to the underlying uilive writer and returns the
+// completion callbacks, if any, that became due during this pass.
+//
+// Rendering happens in two passes so that decorators sharing a width-sync
+// group (see DecoratorGroup) line up across bars: the first pass runs every
+// decorator once and records the widest content seen per group, and the
+// second pass pads each decorator's content to its group's width before
+// assembling the final line.
+func (p *Progress) render() []func() {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	// Auto-detect terminal width and adjust bar widths
 	termWidth := getTerminalWidth()
 
-	for _, bar := range p.Bars {
-		// Calculate available width for the progress bar itself
-		// Account for decorators by checking the current string length
-		bar.mtx.RLock()
-		prependFuncs := make([]DecoratorFunc, len(bar.prependFuncs))
-		copy(prependFuncs, bar.prependFuncs)
-		appendFuncs := make([]DecoratorFunc, len(bar.appendFuncs))
-		copy(appendFuncs, bar.appendFuncs)
-		bar.mtx.RUnlock()
+	prependByBar := make([][]decorContent, len(p.Bars))
+	appendByBar := make([][]decorContent, len(p.Bars))
+	groupWidths := make(map[string]int)
+
+	collect := func(bar *Bar, decs []Decorator) []decorContent {
+		out := make([]decorContent, len(decs))
+		for i, d := range decs {
+			content := d.Decor(bar)
+			group, width := d.Sync()
+			if group != "" && width > groupWidths[group] {
+				groupWidths[group] = width
+			}
+			out[i] = decorContent{content: content, group: group}
+		}
+		return out
+	}
 
-		decoratorsWidth := 0
+	for i, bar := range p.Bars {
+		prependDecs, appendDecs := bar.decorators()
+		prependByBar[i] = collect(bar, prependDecs)
+		appendByBar[i] = collect(bar, appendDecs)
+	}
 
-		// Calculate prepend decorators width
-		for _, f := range prependFuncs {
-			decoratorsWidth += len(f(bar)) + 1 // +1 for space
+	pad := func(dc decorContent) string {
+		if dc.group == "" {
+			return dc.content
 		}
+		return fmt.Sprintf("%-*s", groupWidths[dc.group], dc.content)
+	}
 
-		// Calculate append decorators width
-		for _, f := range appendFuncs {
-			decoratorsWidth += len(f(bar)) + 1 // +1 for space
+	var completions []func()
+
+	for i, bar := range p.Bars {
+		var prepend, appendStr string
+		decoratorsWidth := 0
+
+		for _, dc := range prependByBar[i] {
+			s := pad(dc)
+			prepend += s + " "
+			decoratorsWidth += len(s) + 1
+		}
+		for _, dc := range appendByBar[i] {
+			s := pad(dc)
+			appendStr += " " + s
+			decoratorsWidth += len(s) + 1
 		}
 
 		// Set bar width to terminal width minus decorators
@@ -168,11 +349,19 @@ func (p *Progress) print() {
 
 		bar.mtx.Lock()
 		bar.Width = barWidth
+		line := prepend + bar.render() + appendStr
 		bar.mtx.Unlock()
 
-		fmt.Fprintln(p.lw, bar.String())
+		fmt.Fprintln(p.lw, line)
+
+		if fire := bar.consumeCompletion(); fire != nil {
+			bar := bar
+			completions = append(completions, func() { fire(bar) })
+		}
 	}
 	p.lw.Flush()
+
+	return completions
 }
 
 // Start starts the rendering the progress of progress bars. It listens for updates using `bar.Set(n)` and new bars when added using `AddBar`
@@ -180,13 +369,49 @@ func (p *Progress) Start() {
 	go p.Listen()
 }
 
-// Stop stops listening
+// Stop stops listening, waiting for the current Listen loop to exit. It is
+// safe to call more than once, and safe to call after the context passed to
+// NewWithContext has already ended the loop.
 func (p *Progress) Stop() {
-	p.tdone <- true
-	<-p.tdone
+	p.stopOnce.Do(func() { close(p.done) })
+	<-p.listenDone
 }
 
 // Bypass returns a writer which allows non-buffered data to be written to the underlying output
 func (p *Progress) Bypass() io.Writer {
 	return p.lw.Bypass()
 }
+
+// Println writes a, followed by a newline, above the live progress bars. It
+// is synchronized with the render loop so the line cannot be interleaved
+// with a concurrent redraw.
+func (p *Progress) Println(a ...interface{}) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	fmt.Fprintln(p.lw.Bypass(), a...)
+	// Bypass erased the drawn bars; force the next print to redraw them
+	p.barVersions = make(map[*Bar]int)
+}
+
+// Printf writes a formatted line above the live progress bars. It is
+// synchronized with the render loop so the line cannot be interleaved with a
+// concurrent redraw.
+func (p *Progress) Printf(format string, a ...interface{}) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	fmt.Fprintf(p.lw.Bypass(), format, a...)
+	// Bypass erased the drawn bars; force the next print to redraw them
+	p.barVersions = make(map[*Bar]int)
+}
+
+// Println writes a, followed by a newline, above the live progress bars of
+// the default progress container
+func Println(a ...interface{}) {
+	defaultProgress.Println(a...)
+}
+
+// Printf writes a formatted line above the live progress bars of the
+// default progress container
+func Printf(format string, a ...interface{}) {
+	defaultProgress.Printf(format, a...)
+}
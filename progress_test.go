@@ -0,0 +1,249 @@
+package uiprogress
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProgressPrintSkipsWhenNoBarChanged(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+
+	p.AddBar(10)
+
+	p.print()
+	if buf.Len() == 0 {
+		t.Fatalf("expected the first print to render, got no output")
+	}
+
+	buf.Reset()
+	p.print()
+	if buf.Len() != 0 {
+		t.Fatalf("expected print to skip the flush when no bar changed, got %q", buf.String())
+	}
+}
+
+func TestProgressPrintRendersAfterBarChange(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+
+	bar := p.AddBar(10)
+	p.print()
+	buf.Reset()
+
+	bar.Incr()
+	p.print()
+	if buf.Len() == 0 {
+		t.Fatalf("expected print to render after Incr changed the bar, got no output")
+	}
+}
+
+func TestProgressPrintThrottled(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+	p.SetThrottle(time.Hour)
+
+	bar := p.AddBar(10)
+	p.print() // first render always happens, since the bar set itself changed
+	buf.Reset()
+
+	bar.Incr()
+	p.print()
+	if buf.Len() != 0 {
+		t.Fatalf("expected print to be suppressed within the throttle window, got %q", buf.String())
+	}
+}
+
+func TestProgressRenderNowBypassesDirtyCheckAndThrottle(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+	p.SetThrottle(time.Hour)
+
+	p.AddBar(10)
+	p.print()
+	buf.Reset()
+
+	p.RenderNow()
+	if buf.Len() == 0 {
+		t.Fatalf("expected RenderNow to render even though nothing changed and the throttle window is open")
+	}
+}
+
+func TestProgressBarsChangedAfterAddOrRemove(t *testing.T) {
+	p := New()
+
+	bar := p.AddBar(10)
+	p.mtx.Lock()
+	if !p.barsChangedLocked() {
+		t.Fatalf("expected a freshly added bar to be seen as changed")
+	}
+	p.commitBarVersionsLocked()
+	if p.barsChangedLocked() {
+		t.Fatalf("expected barsChangedLocked to be false right after commitBarVersionsLocked")
+	}
+	p.mtx.Unlock()
+
+	p.RemoveBar(bar)
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if !p.barsChangedLocked() {
+		t.Fatalf("expected removing a bar to be seen as changed")
+	}
+}
+
+func TestProgressRemoveBar(t *testing.T) {
+	p := New()
+	a := p.AddBar(1)
+	b := p.AddBar(1)
+
+	if !p.RemoveBar(a) {
+		t.Fatalf("RemoveBar(a) = false, want true")
+	}
+	if len(p.Bars) != 1 || p.Bars[0] != b {
+		t.Fatalf("expected only b to remain, got %v", p.Bars)
+	}
+	if p.RemoveBar(a) {
+		t.Fatalf("RemoveBar(a) a second time = true, want false")
+	}
+}
+
+func TestProgressSortBars(t *testing.T) {
+	p := New()
+	a := p.AddBar(3)
+	b := p.AddBar(1)
+	c := p.AddBar(2)
+
+	p.SortBars(func(i, j *Bar) bool { return i.Total < j.Total })
+
+	want := []*Bar{b, c, a}
+	for idx, bar := range want {
+		if p.Bars[idx] != bar {
+			t.Fatalf("SortBars order[%d] = %p, want %p", idx, p.Bars[idx], bar)
+		}
+	}
+}
+
+// TestProgressOnCompleteFiresOutsideLockAndIsReentrant guards the
+// requirement that completion callbacks run outside of p.mtx: the callback
+// below calls back into AddBar, which would deadlock if fired while
+// Progress.render() still held the lock.
+func TestProgressOnCompleteFiresOutsideLockAndIsReentrant(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+
+	bar := p.AddBar(1)
+	fired := 0
+	bar.OnComplete(func(b *Bar) {
+		fired++
+		p.AddBar(5)
+	})
+
+	bar.Incr()
+	p.print()
+
+	if fired != 1 {
+		t.Fatalf("OnComplete fired %d times, want 1", fired)
+	}
+	if len(p.Bars) != 2 {
+		t.Fatalf("expected the reentrant AddBar to add a second bar, got %d bars", len(p.Bars))
+	}
+
+	// The reentrant AddBar changed the bar set, so this print renders again;
+	// the callback must still not fire a second time.
+	buf.Reset()
+	p.print()
+	if fired != 1 {
+		t.Fatalf("OnComplete fired again on a later print, want still 1")
+	}
+}
+
+func TestProgressListenStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewWithContext(ctx)
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+	p.SetRefreshInterval(time.Millisecond)
+
+	listenDone := make(chan struct{})
+	go func() {
+		p.Listen()
+		close(listenDone)
+	}()
+
+	cancel()
+
+	select {
+	case <-listenDone:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Listen did not return after context cancellation")
+	}
+}
+
+func TestProgressStopIsIdempotentAfterContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewWithContext(ctx)
+	p.SetRefreshInterval(time.Millisecond)
+
+	go p.Listen()
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.Stop()
+		p.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Stop did not return after the context had already ended Listen")
+	}
+}
+
+// TestProgressPrintlnDoesNotRaceWithRenderNow exercises Println/Printf
+// running concurrently with RenderNow under the race detector (go test -race),
+// and guards against the Bypass-coordination deadlocking.
+func TestProgressPrintlnDoesNotRaceWithRenderNow(t *testing.T) {
+	p := New()
+	var buf bytes.Buffer
+	p.SetOut(&buf)
+	p.AddBar(10)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			p.RenderNow()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			p.Println("log line")
+			p.Printf("log %d\n", i)
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Println/Printf and RenderNow deadlocked when run concurrently")
+	}
+}